@@ -0,0 +1,55 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package internal contains the low-level ABI used to talk to the Redpanda
+// transform host. It is not part of the SDK's public API and may change
+// without notice.
+package internal
+
+// OutputRecord is the wire shape handed to the host for a single record
+// emitted by a transform. Topic is the destination topic; an empty Topic
+// means "the transform's single configured output topic".
+type OutputRecord struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers []OutputHeader
+}
+
+// OutputHeader is the wire shape of a single record header.
+type OutputHeader struct {
+	Key   string
+	Value []byte
+}
+
+// WriteRecords serializes and hands off one or more output records to the
+// host via the write-record host call. It is called at most once per input
+// record processed, after a transform's callback or filter returns; a
+// record dropped by a filter does not reach it.
+func WriteRecords(records []OutputRecord) error {
+	for _, r := range records {
+		if err := writeRecord(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRecord performs the actual host call for a single output record.
+// When built for GOOS=wasip1 GOARCH=wasm, hostWriteRecord serializes r
+// onto the guest/host ABI buffer and invokes the wasm import; on any other
+// platform it returns an error, since there is no host to call.
+func writeRecord(r OutputRecord) error {
+	return hostWriteRecord(r)
+}
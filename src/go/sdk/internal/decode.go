@@ -0,0 +1,82 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// InputRecord is the wire shape of a single record the host hands to the
+// guest's on_record_written export: a record already written to the
+// transform's source topic.
+type InputRecord struct {
+	Key     []byte
+	Value   []byte
+	Headers []OutputHeader
+}
+
+// DecodeInputRecord parses the length-prefixed wire format the host uses
+// when invoking the on_record_written export: key, then value, then each
+// header's key/value, all length-prefixed as uint32 little-endian. It is
+// the inverse of encodeOutputRecord's key/value/header encoding.
+func DecodeInputRecord(buf []byte) (InputRecord, error) {
+	var rec InputRecord
+	var ok bool
+
+	rec.Key, buf, ok = readLenPrefixed(buf)
+	if !ok {
+		return InputRecord{}, fmt.Errorf("redpanda: decoding input record: truncated key")
+	}
+	rec.Value, buf, ok = readLenPrefixed(buf)
+	if !ok {
+		return InputRecord{}, fmt.Errorf("redpanda: decoding input record: truncated value")
+	}
+	if len(buf) < 4 {
+		return InputRecord{}, fmt.Errorf("redpanda: decoding input record: truncated header count")
+	}
+	headerCount := binary.LittleEndian.Uint32(buf)
+	buf = buf[4:]
+
+	rec.Headers = make([]OutputHeader, headerCount)
+	for i := range rec.Headers {
+		var key, value []byte
+		key, buf, ok = readLenPrefixed(buf)
+		if !ok {
+			return InputRecord{}, fmt.Errorf("redpanda: decoding input record: truncated header %d key", i)
+		}
+		value, buf, ok = readLenPrefixed(buf)
+		if !ok {
+			return InputRecord{}, fmt.Errorf("redpanda: decoding input record: truncated header %d value", i)
+		}
+		rec.Headers[i] = OutputHeader{Key: string(key), Value: value}
+	}
+	return rec, nil
+}
+
+// readLenPrefixed reads a single uint32-length-prefixed byte slice off
+// the front of buf, returning the slice, the remaining bytes, and whether
+// buf was long enough to contain it.
+func readLenPrefixed(buf []byte) (data, rest []byte, ok bool) {
+	if len(buf) < 4 {
+		return nil, nil, false
+	}
+	n := binary.LittleEndian.Uint32(buf)
+	buf = buf[4:]
+	if uint32(len(buf)) < n {
+		return nil, nil, false
+	}
+	return buf[:n], buf[n:], true
+}
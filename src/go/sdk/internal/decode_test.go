@@ -0,0 +1,50 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestDecodeInputRecordRoundTrip(t *testing.T) {
+	var buf []byte
+	buf = appendLenPrefixed(buf, []byte("key"))
+	buf = appendLenPrefixed(buf, []byte("value"))
+	buf = binary.LittleEndian.AppendUint32(buf, 1)
+	buf = appendLenPrefixed(buf, []byte("h1"))
+	buf = appendLenPrefixed(buf, []byte("hv1"))
+
+	rec, err := DecodeInputRecord(buf)
+	if err != nil {
+		t.Fatalf("DecodeInputRecord: %v", err)
+	}
+	if !bytes.Equal(rec.Key, []byte("key")) {
+		t.Fatalf("expected key %q, got %q", "key", rec.Key)
+	}
+	if !bytes.Equal(rec.Value, []byte("value")) {
+		t.Fatalf("expected value %q, got %q", "value", rec.Value)
+	}
+	if len(rec.Headers) != 1 || rec.Headers[0].Key != "h1" || !bytes.Equal(rec.Headers[0].Value, []byte("hv1")) {
+		t.Fatalf("unexpected headers: %+v", rec.Headers)
+	}
+}
+
+func TestDecodeInputRecordTruncated(t *testing.T) {
+	if _, err := DecodeInputRecord([]byte{1, 2, 3}); err == nil {
+		t.Fatal("expected an error decoding a truncated buffer")
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// encodeOutputRecord flattens r into the length-prefixed wire format the
+// host's write_record call expects: topic, then key, then value, then each
+// header's key/value, all length-prefixed as uint32 little-endian.
+func encodeOutputRecord(r OutputRecord) []byte {
+	buf := make([]byte, 0, 64+len(r.Value))
+	buf = appendLenPrefixed(buf, []byte(r.Topic))
+	buf = appendLenPrefixed(buf, r.Key)
+	buf = appendLenPrefixed(buf, r.Value)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(r.Headers)))
+	for _, h := range r.Headers {
+		buf = appendLenPrefixed(buf, []byte(h.Key))
+		buf = appendLenPrefixed(buf, h.Value)
+	}
+	return buf
+}
+
+func appendLenPrefixed(buf, data []byte) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(data)))
+	return append(buf, data...)
+}
+
+// errorFromHostCode translates a non-zero host call return code into a Go
+// error.
+func errorFromHostCode(code int32) error {
+	return fmt.Errorf("redpanda: host call failed with code %d", code)
+}
@@ -0,0 +1,26 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !wasip1
+
+package internal
+
+// RunLoop has no host to read records from outside of the wasip1 guest
+// runtime, so it returns immediately. This keeps code that registers a
+// callback and this package's own tests usable when built for the host
+// platform (for example, `go test ./...`, or `go vet` on a developer's
+// machine).
+func RunLoop(process func(InputRecord) error) error {
+	return nil
+}
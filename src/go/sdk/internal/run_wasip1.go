@@ -0,0 +1,51 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build wasip1
+
+package internal
+
+import "unsafe"
+
+//go:wasmimport redpanda_transform read_record
+func readRecordHostCall(bufPtr unsafe.Pointer, bufLen int32) int32
+
+// maxRecordSize bounds the buffer RunLoop reuses across iterations to read
+// each record's wire-format bytes from the host, avoiding an allocation
+// per record.
+const maxRecordSize = 1 << 20 // 1 MiB
+
+// RunLoop repeatedly reads records from the host via the read_record host
+// call and invokes process for each one, until the host reports there are
+// no more records to process (a negative return value from
+// read_record), at which point RunLoop returns nil. It is the host's
+// entry point into the guest: main calls one of OnRecordWritten,
+// OnRecordWrittenTo, or OnRecordWrittenFilter, which call RunLoop, which
+// blocks for the lifetime of the transform.
+func RunLoop(process func(InputRecord) error) error {
+	buf := make([]byte, maxRecordSize)
+	for {
+		n := readRecordHostCall(unsafe.Pointer(&buf[0]), int32(len(buf)))
+		if n < 0 {
+			return nil
+		}
+		rec, err := DecodeInputRecord(buf[:n])
+		if err != nil {
+			return err
+		}
+		if err := process(rec); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+// LogRecord is the wire shape of a single log line shipped to the
+// broker's transform log stream.
+type LogRecord struct {
+	Level   string
+	Message string
+	Attrs   map[string]string
+}
+
+// MetricSample is a single observation against a user-defined counter or
+// histogram, identified by name and the label values it was registered
+// with.
+type MetricSample struct {
+	Name   string
+	Labels []string
+	Value  float64
+}
+
+var (
+	logBuffer     []LogRecord
+	counterDeltas []MetricSample
+	histSamples   []MetricSample
+)
+
+// BufferLogRecord queues a log record to be shipped on the next
+// FlushTelemetry call.
+func BufferLogRecord(r LogRecord) {
+	logBuffer = append(logBuffer, r)
+}
+
+// BufferCounterAdd queues a counter increment to be shipped on the next
+// FlushTelemetry call.
+func BufferCounterAdd(name string, labels []string, delta float64) {
+	counterDeltas = append(counterDeltas, MetricSample{Name: name, Labels: labels, Value: delta})
+}
+
+// BufferHistogramObserve queues a histogram observation to be shipped on
+// the next FlushTelemetry call.
+func BufferHistogramObserve(name string, labels []string, value float64) {
+	histSamples = append(histSamples, MetricSample{Name: name, Labels: labels, Value: value})
+}
+
+// FlushTelemetry hands all buffered log records and metric samples to the
+// host in a single pair of host calls and clears the buffers. It is
+// called once per source record processed, at the transform boundary,
+// rather than once per log line or metric update, to keep host-call
+// overhead off the per-record hot path.
+func FlushTelemetry() error {
+	if len(logBuffer) > 0 {
+		if err := hostFlushLogs(logBuffer); err != nil {
+			return err
+		}
+		logBuffer = logBuffer[:0]
+	}
+	if len(counterDeltas) > 0 || len(histSamples) > 0 {
+		if err := hostFlushMetrics(counterDeltas, histSamples); err != nil {
+			return err
+		}
+		counterDeltas = counterDeltas[:0]
+		histSamples = histSamples[:0]
+	}
+	return nil
+}
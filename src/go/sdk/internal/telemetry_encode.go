@@ -0,0 +1,59 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// encodeLogRecords flattens records into the length-prefixed wire format
+// the host's flush_logs call expects.
+func encodeLogRecords(records []LogRecord) []byte {
+	buf := make([]byte, 0, 64*len(records))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(records)))
+	for _, r := range records {
+		buf = appendLenPrefixed(buf, []byte(r.Level))
+		buf = appendLenPrefixed(buf, []byte(r.Message))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(r.Attrs)))
+		for k, v := range r.Attrs {
+			buf = appendLenPrefixed(buf, []byte(k))
+			buf = appendLenPrefixed(buf, []byte(v))
+		}
+	}
+	return buf
+}
+
+// encodeMetricSamples flattens counters and histograms into the
+// length-prefixed wire format the host's flush_metrics call expects.
+func encodeMetricSamples(counters, histograms []MetricSample) []byte {
+	buf := make([]byte, 0, 32*(len(counters)+len(histograms)))
+	buf = appendMetricSamples(buf, counters)
+	buf = appendMetricSamples(buf, histograms)
+	return buf
+}
+
+func appendMetricSamples(buf []byte, samples []MetricSample) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(samples)))
+	for _, s := range samples {
+		buf = appendLenPrefixed(buf, []byte(s.Name))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(s.Labels)))
+		for _, label := range s.Labels {
+			buf = appendLenPrefixed(buf, []byte(label))
+		}
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(s.Value))
+	}
+	return buf
+}
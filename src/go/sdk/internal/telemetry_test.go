@@ -0,0 +1,32 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import "testing"
+
+func TestFlushTelemetryNoopWhenEmpty(t *testing.T) {
+	if err := FlushTelemetry(); err != nil {
+		t.Fatalf("expected no error flushing empty buffers, got %v", err)
+	}
+}
+
+func TestFlushTelemetryRequiresHostOutsideWasm(t *testing.T) {
+	BufferLogRecord(LogRecord{Level: "INFO", Message: "hello"})
+	t.Cleanup(func() { logBuffer = nil })
+
+	if err := FlushTelemetry(); err == nil {
+		t.Fatal("expected an error: flush_logs host call is unavailable outside GOOS=wasip1")
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build wasip1
+
+package internal
+
+import "unsafe"
+
+//go:wasmimport redpanda_transform flush_logs
+func flushLogsHostCall(ptr unsafe.Pointer, len int32) int32
+
+//go:wasmimport redpanda_transform flush_metrics
+func flushMetricsHostCall(ptr unsafe.Pointer, len int32) int32
+
+func hostFlushLogs(records []LogRecord) error {
+	buf := encodeLogRecords(records)
+	if rc := flushLogsHostCall(unsafe.Pointer(&buf[0]), int32(len(buf))); rc != 0 {
+		return errorFromHostCode(rc)
+	}
+	return nil
+}
+
+func hostFlushMetrics(counters, histograms []MetricSample) error {
+	buf := encodeMetricSamples(counters, histograms)
+	if rc := flushMetricsHostCall(unsafe.Pointer(&buf[0]), int32(len(buf))); rc != 0 {
+		return errorFromHostCode(rc)
+	}
+	return nil
+}
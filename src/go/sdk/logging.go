@@ -0,0 +1,74 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redpanda
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rockwotj/redpanda/src/go/sdk/internal"
+)
+
+// Logger returns an slog.Logger whose records are shipped to the broker's
+// transform log stream. Log lines are buffered in the guest and flushed
+// at transform boundaries, rather than on every call, to keep host-call
+// overhead off the per-record hot path.
+func Logger() *slog.Logger {
+	return slog.New(logHandler{})
+}
+
+// logHandler is an slog.Handler that buffers every record it receives via
+// the internal package, to be flushed by processRecord once the current
+// source record has finished processing.
+type logHandler struct {
+	// prefix is the group path established by WithGroup, applied to
+	// every attribute key, matching slog's convention of "group.key".
+	prefix string
+	// attrs are attributes bound by WithAttrs, carried forward onto
+	// every record this handler (or one derived from it) handles.
+	attrs []slog.Attr
+}
+
+func (logHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h logHandler) Handle(_ context.Context, record slog.Record) error {
+	attrs := make(map[string]string, len(h.attrs)+record.NumAttrs())
+	for _, a := range h.attrs {
+		attrs[h.prefix+a.Key] = a.Value.String()
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		attrs[h.prefix+a.Key] = a.Value.String()
+		return true
+	})
+	if len(attrs) == 0 {
+		attrs = nil
+	}
+	internal.BufferLogRecord(internal.LogRecord{
+		Level:   record.Level.String(),
+		Message: record.Message,
+		Attrs:   attrs,
+	})
+	return nil
+}
+
+func (h logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h.attrs = append(append([]slog.Attr(nil), h.attrs...), attrs...)
+	return h
+}
+
+func (h logHandler) WithGroup(name string) slog.Handler {
+	h.prefix += name + "."
+	return h
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redpanda
+
+import "github.com/rockwotj/redpanda/src/go/sdk/internal"
+
+// CounterMetric is a monotonically increasing user-defined metric,
+// surfaced through Redpanda's /public_metrics endpoint. Obtain one with
+// Counter.
+type CounterMetric struct {
+	name   string
+	labels []string
+}
+
+// Counter returns a counter metric named name with the given label
+// values. Calling Counter repeatedly with the same name and labels
+// returns independent handles that all accumulate into the same
+// underlying metric.
+func Counter(name string, labels ...string) *CounterMetric {
+	return &CounterMetric{name: name, labels: labels}
+}
+
+// Add increments the counter by delta, which must be non-negative. The
+// increment is buffered and flushed to the host at the next transform
+// boundary.
+func (c *CounterMetric) Add(delta float64) {
+	internal.BufferCounterAdd(c.name, c.labels, delta)
+}
+
+// Inc increments the counter by 1.
+func (c *CounterMetric) Inc() {
+	c.Add(1)
+}
+
+// HistogramMetric is a user-defined distribution metric, surfaced through
+// Redpanda's /public_metrics endpoint. Obtain one with Histogram.
+type HistogramMetric struct {
+	name   string
+	labels []string
+}
+
+// Histogram returns a histogram metric named name with the given label
+// values.
+func Histogram(name string, labels ...string) *HistogramMetric {
+	return &HistogramMetric{name: name, labels: labels}
+}
+
+// Observe records value as a single observation. The observation is
+// buffered and flushed to the host at the next transform boundary.
+func (h *HistogramMetric) Observe(value float64) {
+	internal.BufferHistogramObserve(h.name, h.labels, value)
+}
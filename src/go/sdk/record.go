@@ -0,0 +1,84 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redpanda
+
+// RecordHeader is a single Kafka-style header attached to a Record.
+type RecordHeader struct {
+	Key   string
+	Value []byte
+}
+
+// Record is a single record, either read from the transform's source
+// topic or produced by the transform for one of its sink topics.
+type Record struct {
+	Key     []byte
+	Value   []byte
+	Headers []RecordHeader
+
+	// Topic is the destination topic this record should be written to.
+	// It is ignored on records read from the source topic. On records
+	// returned from an OnRecordWritten callback, an empty Topic means
+	// "the transform's single configured output topic"; transforms
+	// registered via OnRecordWrittenTo must set Topic to one of the
+	// topics they declared.
+	Topic string
+}
+
+// headerIndex returns the index of the first header with the given key, or -1
+// if there is none.
+func (r Record) headerIndex(key string) int {
+	for i, h := range r.Headers {
+		if h.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Clone returns a deep copy of r: Key, Value, Headers, and each header's
+// Value get their own backing storage, so callers can fan one input
+// record out into several output records and mutate each independently
+// without any of them aliasing r or each other.
+func (r Record) Clone() Record {
+	clone := r
+	clone.Key = append([]byte(nil), r.Key...)
+	clone.Value = append([]byte(nil), r.Value...)
+	if r.Headers != nil {
+		clone.Headers = make([]RecordHeader, len(r.Headers))
+		for i, h := range r.Headers {
+			clone.Headers[i] = RecordHeader{Key: h.Key, Value: append([]byte(nil), h.Value...)}
+		}
+	}
+	return clone
+}
+
+// WithKey returns a copy of r with Key set to key.
+func (r Record) WithKey(key []byte) Record {
+	clone := r.Clone()
+	clone.Key = key
+	return clone
+}
+
+// WithHeader returns a copy of r with a header named key set to value,
+// replacing any existing header with that key.
+func (r Record) WithHeader(key string, value []byte) Record {
+	clone := r.Clone()
+	if i := clone.headerIndex(key); i >= 0 {
+		clone.Headers[i].Value = value
+	} else {
+		clone.Headers = append(clone.Headers, RecordHeader{Key: key, Value: value})
+	}
+	return clone
+}
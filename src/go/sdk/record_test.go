@@ -0,0 +1,71 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redpanda
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRecordWithKeyDoesNotMutateOriginal(t *testing.T) {
+	original := Record{Key: []byte("k1"), Value: []byte("v")}
+	fanned := original.WithKey([]byte("k2"))
+
+	if !bytes.Equal(original.Key, []byte("k1")) {
+		t.Fatalf("expected original key to be unchanged, got %q", original.Key)
+	}
+	if !bytes.Equal(fanned.Key, []byte("k2")) {
+		t.Fatalf("expected fanned out key %q, got %q", "k2", fanned.Key)
+	}
+}
+
+func TestRecordCloneDoesNotAliasBackingArrays(t *testing.T) {
+	original := Record{
+		Key:     []byte("k"),
+		Value:   []byte("v"),
+		Headers: []RecordHeader{{Key: "a", Value: []byte("1")}},
+	}
+	clone := original.Clone()
+
+	clone.Key[0] = 'x'
+	clone.Value[0] = 'x'
+	clone.Headers[0].Value[0] = 'x'
+
+	if !bytes.Equal(original.Key, []byte("k")) {
+		t.Fatalf("expected original Key to be unchanged, got %q", original.Key)
+	}
+	if !bytes.Equal(original.Value, []byte("v")) {
+		t.Fatalf("expected original Value to be unchanged, got %q", original.Value)
+	}
+	if !bytes.Equal(original.Headers[0].Value, []byte("1")) {
+		t.Fatalf("expected original header Value to be unchanged, got %q", original.Headers[0].Value)
+	}
+}
+
+func TestRecordWithHeaderReplacesExisting(t *testing.T) {
+	r := Record{Headers: []RecordHeader{{Key: "a", Value: []byte("1")}}}
+
+	r2 := r.WithHeader("a", []byte("2")).WithHeader("b", []byte("3"))
+
+	if len(r.Headers) != 1 {
+		t.Fatalf("expected original headers to be unchanged, got %v", r.Headers)
+	}
+	if len(r2.Headers) != 2 {
+		t.Fatalf("expected 2 headers, got %d", len(r2.Headers))
+	}
+	if !bytes.Equal(r2.Headers[0].Value, []byte("2")) {
+		t.Fatalf("expected header %q to be replaced, got %q", "a", r2.Headers[0].Value)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sr
+
+import (
+	"fmt"
+
+	"github.com/hamba/avro/v2"
+)
+
+// DecodeAvro decodes an Avro-encoded Schema Registry payload into v, using
+// client to resolve the schema referenced by the payload's schema ID.
+func DecodeAvro(client Client, value []byte, v any) error {
+	id, payload, err := Decode(value)
+	if err != nil {
+		return err
+	}
+	schemaText, err := client.Schema(id)
+	if err != nil {
+		return err
+	}
+	schema, err := avro.Parse(schemaText)
+	if err != nil {
+		return fmt.Errorf("sr: parsing avro schema %d: %w", id, err)
+	}
+	if err := avro.Unmarshal(schema, payload, v); err != nil {
+		return fmt.Errorf("sr: decoding avro payload: %w", err)
+	}
+	return nil
+}
+
+// EncodeAvro encodes v with schema and wraps the result in the Schema
+// Registry wire format under schemaID.
+func EncodeAvro(schemaID uint32, schema avro.Schema, v any) ([]byte, error) {
+	payload, err := avro.Marshal(schema, v)
+	if err != nil {
+		return nil, fmt.Errorf("sr: encoding avro payload: %w", err)
+	}
+	return Encode(schemaID, payload), nil
+}
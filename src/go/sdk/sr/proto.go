@@ -0,0 +1,49 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sr
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// DecodeProto decodes a Protobuf-encoded Schema Registry payload into msg.
+// client is consulted to confirm a schema is registered for the payload's
+// ID; the protobuf bytes themselves decode directly via msg's own
+// descriptor.
+func DecodeProto(client Client, value []byte, msg proto.Message) error {
+	id, payload, err := Decode(value)
+	if err != nil {
+		return err
+	}
+	if _, err := client.Schema(id); err != nil {
+		return err
+	}
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return fmt.Errorf("sr: decoding protobuf payload: %w", err)
+	}
+	return nil
+}
+
+// EncodeProto marshals msg and wraps the result in the Schema Registry
+// wire format under schemaID.
+func EncodeProto(schemaID uint32, msg proto.Message) ([]byte, error) {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("sr: encoding protobuf payload: %w", err)
+	}
+	return Encode(schemaID, payload), nil
+}
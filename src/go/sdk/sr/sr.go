@@ -0,0 +1,77 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sr helps transforms read and write records whose Key or Value
+// are Schema Registry wire-format payloads: a leading magic byte, a
+// 4-byte big-endian schema ID, then the Avro/Protobuf/JSON Schema encoded
+// payload.
+package sr
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const magicByte = 0x00
+
+// ErrInvalidWireFormat is returned by Decode when a value is too short to
+// contain a Schema Registry header, or doesn't start with the expected
+// magic byte.
+var ErrInvalidWireFormat = errors.New("sr: value is not a valid Schema Registry wire-format payload")
+
+// Decode splits a Schema Registry wire-format payload into its schema ID
+// and the encoded payload that follows the header.
+func Decode(value []byte) (schemaID uint32, payload []byte, err error) {
+	if len(value) < 5 || value[0] != magicByte {
+		return 0, nil, ErrInvalidWireFormat
+	}
+	return binary.BigEndian.Uint32(value[1:5]), value[5:], nil
+}
+
+// Encode prepends the Schema Registry wire-format header for schemaID to
+// payload.
+func Encode(schemaID uint32, payload []byte) []byte {
+	out := make([]byte, 5+len(payload))
+	out[0] = magicByte
+	binary.BigEndian.PutUint32(out[1:5], schemaID)
+	copy(out[5:], payload)
+	return out
+}
+
+// Client resolves a Schema Registry schema ID to its raw schema text. The
+// WASM guest can't make arbitrary HTTP calls, so implementations are
+// expected to be backed either by a cache seeded at transform init (see
+// NewCachedClient) or, in the future, a host call.
+type Client interface {
+	// Schema returns the raw schema text registered under id.
+	Schema(id uint32) (schema string, err error)
+}
+
+// NewCachedClient returns a Client backed by an in-memory map of schema ID
+// to raw schema text, typically seeded once at transform init from
+// schemas baked into the WASM binary.
+func NewCachedClient(schemas map[uint32]string) Client {
+	return cachedClient(schemas)
+}
+
+type cachedClient map[uint32]string
+
+func (c cachedClient) Schema(id uint32) (string, error) {
+	schema, ok := c[id]
+	if !ok {
+		return "", fmt.Errorf("sr: no schema cached for id %d", id)
+	}
+	return schema, nil
+}
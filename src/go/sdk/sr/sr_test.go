@@ -0,0 +1,58 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sr_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rockwotj/redpanda/src/go/sdk/sr"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	payload := []byte(`{"hello":"world"}`)
+	wire := sr.Encode(7, payload)
+
+	id, got, err := sr.Decode(wire)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if id != 7 {
+		t.Fatalf("expected schema id 7, got %d", id)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Fatalf("expected payload %q, got %q", payload, got)
+	}
+}
+
+func TestDecodeInvalidWireFormat(t *testing.T) {
+	if _, _, err := sr.Decode([]byte("too short")); err == nil {
+		t.Fatal("expected an error for a value without the magic byte")
+	}
+	if _, _, err := sr.Decode([]byte{0x01, 0, 0, 0, 1, 'x'}); err == nil {
+		t.Fatal("expected an error for a value with the wrong magic byte")
+	}
+}
+
+func TestCachedClient(t *testing.T) {
+	client := sr.NewCachedClient(map[uint32]string{1: `"string"`})
+
+	if _, err := client.Schema(1); err != nil {
+		t.Fatalf("expected schema 1 to be cached: %v", err)
+	}
+	if _, err := client.Schema(2); err == nil {
+		t.Fatal("expected an error for an uncached schema id")
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redpanda
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rockwotj/redpanda/src/go/sdk/internal"
+)
+
+// WriteEvent is passed to the callback registered with OnRecordWritten (or
+// OnRecordWrittenTo) for each record read from the transform's source
+// topic.
+type WriteEvent struct {
+	record Record
+}
+
+// Record returns the source record this event was created for.
+func (e WriteEvent) Record() Record {
+	return e.record
+}
+
+// onRecordWrittenCallback is the shape of a transform's processing
+// function: given the record that was written to the source topic, return
+// zero or more records to emit, or an error to fail processing of this
+// record.
+type onRecordWrittenCallback func(e WriteEvent) ([]Record, error)
+
+// Drop returns the nil []Record that, returned alongside a nil error from
+// an OnRecordWritten (or OnRecordWrittenTo) callback, explicitly signals
+// that a source record should produce no output records. Returning
+// (nil, nil) directly has the same effect; Drop exists purely for
+// readability at call sites, e.g. `return redpanda.Drop(), nil`.
+func Drop() []Record {
+	return nil
+}
+
+// registration holds the callback (or filter) and the topics it is
+// allowed to write to. A nil or empty sinkTopics means the transform was
+// registered with OnRecordWritten, OnRecordWrittenFilter, or
+// OnRecordWrittenTo with no topics, and only ever targets its single
+// configured output topic.
+var registration struct {
+	callback   onRecordWrittenCallback
+	filter     func(e WriteEvent) (bool, error)
+	sinkTopics []string
+}
+
+// OnRecordWritten registers fn to be called for every record written to
+// the transform's source topic. The records fn returns are written to the
+// transform's single configured output topic; any non-empty Topic field
+// they carry is ignored. Returning (nil, nil), or (redpanda.Drop(), nil),
+// drops the source record without emitting anything. Use OnRecordWrittenTo
+// to target more than one sink topic, or OnRecordWrittenFilter for a
+// simple keep/drop transform.
+//
+// OnRecordWritten should be called once, from main. It blocks, processing
+// records as the host hands them to the guest, until the host reports
+// there are none left, at which point it returns and the transform exits.
+func OnRecordWritten(fn func(e WriteEvent) ([]Record, error)) {
+	registration.callback = onRecordWrittenCallback(fn)
+	registration.filter = nil
+	registration.sinkTopics = nil
+	run()
+}
+
+// OnRecordWrittenTo registers fn to be called for every record written to
+// the transform's source topic, declaring topics as the complete set of
+// sink topics fn is allowed to direct output records to. Every Record
+// returned by fn must set Topic to one of topics; processing the record
+// fails otherwise. If topics is empty, no topic is checked: Topic is
+// cleared and records go to the single configured output topic, as with
+// OnRecordWritten.
+//
+// OnRecordWrittenTo should be called once, from main. It blocks, processing
+// records as the host hands them to the guest, until the host reports
+// there are none left, at which point it returns and the transform exits.
+func OnRecordWrittenTo(topics []string, fn func(e WriteEvent) ([]Record, error)) {
+	registration.callback = onRecordWrittenCallback(fn)
+	registration.filter = nil
+	registration.sinkTopics = topics
+	run()
+}
+
+// OnRecordWrittenFilter registers fn to be called for every record written
+// to the transform's source topic. fn reports whether the record should be
+// kept, in which case it is written unchanged to the transform's single
+// configured output topic, or dropped. OnRecordWrittenFilter is meant for
+// simple filtering transforms: unlike OnRecordWritten, it never requires
+// the caller to allocate a []Record just to return the one record it read.
+//
+// OnRecordWrittenFilter should be called once, from main. It blocks,
+// processing records as the host hands them to the guest, until the host
+// reports there are none left, at which point it returns and the
+// transform exits.
+func OnRecordWrittenFilter(fn func(e WriteEvent) (bool, error)) {
+	registration.callback = nil
+	registration.filter = fn
+	registration.sinkTopics = nil
+	run()
+}
+
+// run hands the guest over to the host: it blocks reading records via
+// internal.RunLoop and dispatching each through processRecord, until the
+// host reports there are no more records to process. On the host
+// platform (building for anything other than GOOS=wasip1, as when this
+// package's own tests run), there is no host to read records from, so
+// internal.RunLoop returns immediately and run is a no-op.
+func run() {
+	if err := internal.RunLoop(dispatchInputRecord); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// dispatchInputRecord converts a record read from the host into a
+// WriteEvent and runs it through processRecord.
+func dispatchInputRecord(in internal.InputRecord) error {
+	return processRecord(WriteEvent{record: Record{
+		Key:     in.Key,
+		Value:   in.Value,
+		Headers: toRecordHeaders(in.Headers),
+	}})
+}
+
+func toRecordHeaders(headers []internal.OutputHeader) []RecordHeader {
+	if len(headers) == 0 {
+		return nil
+	}
+	out := make([]RecordHeader, len(headers))
+	for i, h := range headers {
+		out[i] = RecordHeader{Key: h.Key, Value: h.Value}
+	}
+	return out
+}
+
+// processRecord is invoked by the host runtime once per record read from
+// the source topic. It runs the registered callback or filter, hands any
+// resulting records off to the host, and flushes buffered logs and
+// metrics at this transform boundary.
+func processRecord(e WriteEvent) (err error) {
+	defer func() {
+		if flushErr := internal.FlushTelemetry(); err == nil {
+			err = flushErr
+		}
+	}()
+
+	switch {
+	case registration.filter != nil:
+		return processFilteredRecord(e)
+	case registration.callback != nil:
+		return processCallbackRecord(e)
+	default:
+		return fmt.Errorf("redpanda: no callback registered, call OnRecordWritten, OnRecordWrittenTo, or OnRecordWrittenFilter from main")
+	}
+}
+
+func processCallbackRecord(e WriteEvent) error {
+	out, err := registration.callback(e)
+	if err != nil {
+		return err
+	}
+	records := make([]internal.OutputRecord, 0, len(out))
+	for _, r := range out {
+		if err := validateOutputTopic(r.Topic); err != nil {
+			return err
+		}
+		records = append(records, toOutputRecord(sanitizeOutputTopic(r)))
+	}
+	return internal.WriteRecords(records)
+}
+
+func processFilteredRecord(e WriteEvent) error {
+	keep, err := registration.filter(e)
+	if err != nil {
+		return err
+	}
+	if !keep {
+		return nil
+	}
+	return internal.WriteRecords([]internal.OutputRecord{toOutputRecord(sanitizeOutputTopic(e.record))})
+}
+
+// validateOutputTopic checks topic against the sink topics declared at
+// registration time, if any were declared. A transform in single-sink
+// mode (no declared sink topics) may set any Topic, since
+// sanitizeOutputTopic clears it before the record is serialized.
+func validateOutputTopic(topic string) error {
+	if len(registration.sinkTopics) == 0 {
+		return nil
+	}
+	for _, t := range registration.sinkTopics {
+		if t == topic {
+			return nil
+		}
+	}
+	return fmt.Errorf("redpanda: output record targets topic %q, which was not declared in OnRecordWrittenTo", topic)
+}
+
+// sanitizeOutputTopic clears r.Topic when the transform was registered
+// for a single configured sink topic (OnRecordWritten or
+// OnRecordWrittenFilter), per the documented contract that Topic is
+// ignored in that mode. Transforms registered via OnRecordWrittenTo keep
+// whichever declared topic they set.
+func sanitizeOutputTopic(r Record) Record {
+	if len(registration.sinkTopics) == 0 {
+		r.Topic = ""
+	}
+	return r
+}
+
+func toOutputRecord(r Record) internal.OutputRecord {
+	headers := make([]internal.OutputHeader, len(r.Headers))
+	for i, h := range r.Headers {
+		headers[i] = internal.OutputHeader{Key: h.Key, Value: h.Value}
+	}
+	return internal.OutputRecord{
+		Topic:   r.Topic,
+		Key:     r.Key,
+		Value:   r.Value,
+		Headers: headers,
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redpanda
+
+import (
+	"testing"
+
+	"github.com/rockwotj/redpanda/src/go/sdk/internal"
+)
+
+func TestValidateOutputTopic(t *testing.T) {
+	t.Cleanup(func() { registration.sinkTopics = nil })
+
+	registration.sinkTopics = nil
+	if err := validateOutputTopic("anything"); err != nil {
+		t.Fatalf("expected no sink topics to allow any topic, got %v", err)
+	}
+
+	registration.sinkTopics = []string{"a", "b"}
+	if err := validateOutputTopic("a"); err != nil {
+		t.Fatalf("expected %q to be allowed, got %v", "a", err)
+	}
+	if err := validateOutputTopic("c"); err == nil {
+		t.Fatal("expected undeclared topic to be rejected")
+	}
+}
+
+func TestSanitizeOutputTopic(t *testing.T) {
+	t.Cleanup(func() { registration.sinkTopics = nil })
+
+	registration.sinkTopics = nil
+	if got := sanitizeOutputTopic(Record{Topic: "sneaky-topic"}); got.Topic != "" {
+		t.Fatalf("expected Topic to be cleared in single-sink mode, got %q", got.Topic)
+	}
+
+	registration.sinkTopics = []string{"a", "b"}
+	if got := sanitizeOutputTopic(Record{Topic: "a"}); got.Topic != "a" {
+		t.Fatalf("expected Topic %q to be preserved when sink topics are declared, got %q", "a", got.Topic)
+	}
+}
+
+func TestDispatchInputRecord(t *testing.T) {
+	t.Cleanup(func() {
+		registration.callback = nil
+		registration.filter = nil
+	})
+
+	var got WriteEvent
+	OnRecordWritten(func(e WriteEvent) ([]Record, error) {
+		got = e
+		return Drop(), nil
+	})
+
+	in := internal.InputRecord{
+		Key:     []byte("k"),
+		Value:   []byte("v"),
+		Headers: []internal.OutputHeader{{Key: "h", Value: []byte("hv")}},
+	}
+	if err := dispatchInputRecord(in); err != nil {
+		t.Fatalf("dispatchInputRecord: %v", err)
+	}
+	if string(got.Record().Key) != "k" || string(got.Record().Value) != "v" {
+		t.Fatalf("unexpected record: %+v", got.Record())
+	}
+	if len(got.Record().Headers) != 1 || got.Record().Headers[0].Key != "h" {
+		t.Fatalf("unexpected headers: %+v", got.Record().Headers)
+	}
+}
+
+func TestOnRecordWrittenFilterDrop(t *testing.T) {
+	t.Cleanup(func() {
+		registration.callback = nil
+		registration.filter = nil
+	})
+
+	OnRecordWrittenFilter(func(e WriteEvent) (bool, error) {
+		return false, nil
+	})
+
+	if err := processRecord(WriteEvent{record: Record{Value: []byte("x")}}); err != nil {
+		t.Fatalf("dropping a record should not error, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+)
+
+// adminClient is a minimal client for the subset of the Redpanda admin API
+// needed to deploy a WASM transform for a test run.
+type adminClient struct {
+	addr       string
+	httpClient *http.Client
+}
+
+func newAdminClient(addr string) *adminClient {
+	return &adminClient{addr: addr, httpClient: http.DefaultClient}
+}
+
+// deployRequest describes a transform to deploy via the admin API.
+type deployRequest struct {
+	Name         string
+	InputTopic   string
+	OutputTopics []string
+	WasmBinary   string
+}
+
+type deployMetadata struct {
+	Name         string   `json:"name"`
+	InputTopic   string   `json:"input_topic"`
+	OutputTopics []string `json:"output_topics"`
+}
+
+// deployTransform uploads the compiled WASM binary along with its metadata
+// to the admin API's transform deploy endpoint.
+func (c *adminClient) deployTransform(ctx context.Context, req deployRequest) error {
+	wasm, err := os.Open(req.WasmBinary)
+	if err != nil {
+		return fmt.Errorf("transformtest: opening wasm binary: %w", err)
+	}
+	defer wasm.Close()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	metaPart, err := mw.CreateFormField("metadata")
+	if err != nil {
+		return fmt.Errorf("transformtest: creating metadata part: %w", err)
+	}
+	if err := json.NewEncoder(metaPart).Encode(deployMetadata{
+		Name:         req.Name,
+		InputTopic:   req.InputTopic,
+		OutputTopics: req.OutputTopics,
+	}); err != nil {
+		return fmt.Errorf("transformtest: encoding metadata: %w", err)
+	}
+
+	wasmPart, err := mw.CreateFormFile("wasm_binary", req.Name+".wasm")
+	if err != nil {
+		return fmt.Errorf("transformtest: creating wasm part: %w", err)
+	}
+	if _, err := io.Copy(wasmPart, wasm); err != nil {
+		return fmt.Errorf("transformtest: copying wasm binary: %w", err)
+	}
+	if err := mw.Close(); err != nil {
+		return fmt.Errorf("transformtest: closing multipart writer: %w", err)
+	}
+
+	url := fmt.Sprintf("http://%s/v1/transform/deploy", c.addr)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &body)
+	if err != nil {
+		return fmt.Errorf("transformtest: building deploy request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", mw.FormDataContentType())
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("transformtest: deploying transform: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("transformtest: deploy request failed with status %s: %s", resp.Status, msg)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transformtest lets SDK users exercise a compiled WASM transform
+// end-to-end against a real Redpanda broker, instead of only in-process
+// mocks. It builds the transform under test, deploys it to a disposable
+// Redpanda container started via testcontainers-go, produces fixture
+// records to the transform's source topic, and hands back whatever the
+// transform writes to its sink topic(s).
+package transformtest
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	redpandatc "github.com/testcontainers/testcontainers-go/modules/redpanda"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const defaultImage = "docker.redpanda.com/redpandadata/redpanda:latest"
+
+const defaultTimeout = 30 * time.Second
+
+// Fixture is a single record produced to a Config's SourceTopic once the
+// transform under test has been deployed.
+type Fixture struct {
+	Key     []byte
+	Value   []byte
+	Headers []kgo.RecordHeader
+}
+
+// Config describes a single Run of a compiled transform against a live
+// Redpanda broker.
+type Config struct {
+	// TransformDir is the directory containing the transform's main
+	// package. It is built with GOOS=wasip1 GOARCH=wasm before being
+	// deployed.
+	// Defaults to the current directory.
+	TransformDir string
+	// Name is the name the transform is deployed under. Defaults to the
+	// base name of TransformDir.
+	Name string
+	// SourceTopic is the topic the transform reads from. It is created
+	// automatically if it does not already exist.
+	SourceTopic string
+	// SinkTopics are the topic(s) the transform writes to. They are
+	// created automatically if they do not already exist.
+	SinkTopics []string
+	// Fixtures are produced to SourceTopic once the transform is deployed
+	// and running.
+	Fixtures []Fixture
+	// Image overrides the Redpanda container image used for the test.
+	// Defaults to an image known to support WASM transforms.
+	Image string
+	// Timeout bounds how long Run waits for records to appear on
+	// SinkTopics. Defaults to 30s.
+	Timeout time.Duration
+}
+
+// Run builds the transform rooted at cfg.TransformDir, deploys it to a
+// disposable Redpanda container started with WASM transforms enabled,
+// produces cfg.Fixtures to cfg.SourceTopic, and returns every record
+// observed on cfg.SinkTopics before cfg.Timeout elapses, keyed by topic.
+//
+// Run is meant to be called from a Go test: it calls t.Fatal on any setup
+// failure and tears the container down via t.Cleanup.
+func Run(t *testing.T, cfg Config) map[string][]*kgo.Record {
+	t.Helper()
+	cfg = withDefaults(cfg)
+	ctx := context.Background()
+
+	wasmPath := buildTransform(t, cfg.TransformDir)
+
+	container, err := redpandatc.Run(ctx, cfg.Image, redpandatc.WithEnableWasmTransform())
+	if err != nil {
+		t.Fatalf("transformtest: starting redpanda container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := testcontainers.TerminateContainer(container); err != nil {
+			t.Logf("transformtest: terminating container: %v", err)
+		}
+	})
+
+	brokers, err := container.KafkaSeedBroker(ctx)
+	if err != nil {
+		t.Fatalf("transformtest: fetching seed broker: %v", err)
+	}
+
+	client, err := kgo.NewClient(kgo.SeedBrokers(brokers))
+	if err != nil {
+		t.Fatalf("transformtest: creating kafka client: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	admin := kadm.NewClient(client)
+	topics := append([]string{cfg.SourceTopic}, cfg.SinkTopics...)
+	if _, err := admin.CreateTopics(ctx, 1, 1, nil, topics...); err != nil {
+		t.Fatalf("transformtest: creating topics: %v", err)
+	}
+
+	adminAddr, err := container.AdminAPIAddress(ctx)
+	if err != nil {
+		t.Fatalf("transformtest: fetching admin api address: %v", err)
+	}
+	if err := deployTransform(ctx, adminAddr, cfg.Name, cfg.SourceTopic, cfg.SinkTopics, wasmPath); err != nil {
+		t.Fatalf("transformtest: deploying transform: %v", err)
+	}
+
+	produceFixtures(ctx, t, client, cfg.SourceTopic, cfg.Fixtures)
+
+	return collectRecords(ctx, t, client, cfg.SinkTopics, cfg.Timeout)
+}
+
+func withDefaults(cfg Config) Config {
+	if cfg.TransformDir == "" {
+		cfg.TransformDir = "."
+	}
+	if cfg.Name == "" {
+		abs, err := filepath.Abs(cfg.TransformDir)
+		if err == nil {
+			cfg.Name = filepath.Base(abs)
+		} else {
+			cfg.Name = "transformtest"
+		}
+	}
+	if cfg.Image == "" {
+		cfg.Image = defaultImage
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	return cfg
+}
+
+// buildTransform compiles the transform rooted at dir to a WASM binary and
+// returns the path to the resulting artifact.
+func buildTransform(t *testing.T, dir string) string {
+	t.Helper()
+	out := filepath.Join(t.TempDir(), "transform.wasm")
+	cmd := exec.Command("go", "build", "-o", out, ".")
+	cmd.Dir = dir
+	cmd.Env = append(cmd.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("transformtest: building %s for wasip1/wasm: %v\n%s", dir, err, output)
+	}
+	return out
+}
+
+func produceFixtures(ctx context.Context, t *testing.T, client *kgo.Client, topic string, fixtures []Fixture) {
+	t.Helper()
+	var results kgo.ProduceResults
+	for _, f := range fixtures {
+		record := &kgo.Record{Topic: topic, Key: f.Key, Value: f.Value, Headers: f.Headers}
+		results = append(results, client.ProduceSync(ctx, record)...)
+	}
+	if err := results.FirstErr(); err != nil {
+		t.Fatalf("transformtest: producing fixtures to %s: %v", topic, err)
+	}
+}
+
+// collectRecords consumes sinkTopics until timeout elapses, returning
+// whatever records were observed, keyed by topic.
+func collectRecords(ctx context.Context, t *testing.T, client *kgo.Client, sinkTopics []string, timeout time.Duration) map[string][]*kgo.Record {
+	t.Helper()
+	client.AddConsumeTopics(sinkTopics...)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	out := make(map[string][]*kgo.Record, len(sinkTopics))
+	for {
+		fetches := client.PollFetches(ctx)
+		if err := ctx.Err(); err != nil {
+			return out
+		}
+		fetches.EachError(func(topic string, partition int32, err error) {
+			t.Logf("transformtest: fetch error on %s/%d: %v", topic, partition, err)
+		})
+		fetches.EachRecord(func(r *kgo.Record) {
+			out[r.Topic] = append(out[r.Topic], r)
+		})
+	}
+}
+
+func deployTransform(ctx context.Context, adminAddr, name, sourceTopic string, sinkTopics []string, wasmPath string) error {
+	client := newAdminClient(adminAddr)
+	return client.deployTransform(ctx, deployRequest{
+		Name:         name,
+		InputTopic:   sourceTopic,
+		OutputTopics: sinkTopics,
+		WasmBinary:   wasmPath,
+	})
+}
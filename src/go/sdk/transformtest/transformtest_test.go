@@ -0,0 +1,46 @@
+// Copyright 2023 Redpanda Data, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transformtest_test
+
+import (
+	"testing"
+
+	"github.com/rockwotj/redpanda/src/go/sdk/transformtest"
+)
+
+// This exercises the identity transform in testdata/identity against a
+// real, disposable Redpanda broker.
+func TestRun_identityTransform(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts a Redpanda container; skipping in -short mode")
+	}
+
+	out := transformtest.Run(t, transformtest.Config{
+		TransformDir: "testdata/identity",
+		SourceTopic:  "input",
+		SinkTopics:   []string{"output"},
+		Fixtures: []transformtest.Fixture{
+			{Key: []byte("k"), Value: []byte("v")},
+		},
+	})
+
+	records := out["output"]
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record on output, got %d", len(records))
+	}
+	if string(records[0].Value) != "v" {
+		t.Fatalf("expected value %q, got %q", "v", records[0].Value)
+	}
+}